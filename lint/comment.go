@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// CommentStyle describes how a comment block looks in a particular source
+// language. Either LinePrefix is set (one comment marker per line) or
+// BlockStart/BlockEnd are set (a single wrapping block comment).
+type CommentStyle struct {
+	LinePrefix string
+	BlockStart string
+	BlockEnd   string
+}
+
+// commentStyles is the registry of comment styles HeaderLinter knows how to
+// render and recognize, keyed by a short style name.
+var commentStyles = map[string]CommentStyle{
+	"//":   {LinePrefix: "//"},
+	"/**/": {BlockStart: "/*", BlockEnd: "*/"},
+	"#":    {LinePrefix: "#"},
+	"<!--": {BlockStart: "<!--", BlockEnd: "-->"},
+	";":    {LinePrefix: ";"},
+	"%":    {LinePrefix: "%"},
+}
+
+// extensionStyles maps a file extension to the name of its default comment
+// style. Projects can override individual extensions via the
+// comment-style-overrides config key.
+var extensionStyles = map[string]string{
+	".go":   "/**/",
+	".java": "/**/",
+	".c":    "/**/",
+	".h":    "/**/",
+	".cc":   "/**/",
+	".cpp":  "/**/",
+	".js":   "//",
+	".jsx":  "//",
+	".ts":   "//",
+	".tsx":  "//",
+	".py":   "#",
+	".sh":   "#",
+	".bash": "#",
+	".yaml": "#",
+	".yml":  "#",
+	".toml": "#",
+	".xml":  "<!--",
+	".html": "<!--",
+	".lisp": ";",
+	".el":   ";",
+	".tex":  "%",
+}
+
+// styleForFile resolves the comment style to use for path, honoring
+// per-extension overrides from the config before falling back to the
+// built-in registry. It returns false if the extension is unknown and the
+// file should be skipped.
+func styleForFile(cfg *Config, path string) (CommentStyle, bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	name, ok := cfg.CommentStyleOverrides[ext]
+	if !ok {
+		name, ok = extensionStyles[ext]
+		if !ok {
+			return CommentStyle{}, false
+		}
+	}
+
+	style, ok := commentStyles[name]
+	return style, ok
+}
+
+// render wraps license content in the given comment style, producing the
+// exact header text that should appear at the top of a source file.
+func (s CommentStyle) render(content string) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	if s.BlockStart != "" {
+		var b strings.Builder
+		b.WriteString(s.BlockStart + "\n")
+		for _, line := range lines {
+			b.WriteString(" * " + line + "\n")
+		}
+		b.WriteString(" " + s.BlockEnd + "\n")
+		return b.String()
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(s.LinePrefix+" "+line, " ") + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// defaultConfigFile is the config file HeaderLinter looks for when none is
+// given explicitly, following the convention used by other ASF license
+// tooling.
+const defaultConfigFile = ".licenserc.yaml"
+
+// LicenseConfig describes the license the repository's source files must
+// carry.
+type LicenseConfig struct {
+	SPDXID  string `yaml:"spdx-id"`
+	Content string `yaml:"content"`
+}
+
+// Config is the root of .licenserc.yaml.
+type Config struct {
+	Paths                 []string          `yaml:"paths"`
+	PathsIgnore           []string          `yaml:"paths-ignore"`
+	License               LicenseConfig     `yaml:"license"`
+	CommentStyleOverrides map[string]string `yaml:"comment-style-overrides"`
+	Policy                PolicyConfig      `yaml:"policy"`
+
+	// LicenseLocationThreshold is how many lines into a file, past any
+	// recognized leading pragma (shebang, XML prolog, Go build
+	// constraints, ...), the header may start and still count as present.
+	// Zero means "use the default".
+	LicenseLocationThreshold int `yaml:"license-location-threshold"`
+}
+
+// licenseLocationThreshold returns the configured threshold, or the
+// package default when the config leaves it unset.
+func (c *Config) licenseLocationThreshold() int {
+	if c.LicenseLocationThreshold > 0 {
+		return c.LicenseLocationThreshold
+	}
+	return defaultLicenseLocationThreshold
+}
+
+// LoadConfig reads and parses a .licenserc.yaml file. A missing license
+// spdx-id defaults to Apache-2.0, the license this repository is under.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	if cfg.License.SPDXID == "" {
+		cfg.License.SPDXID = "Apache-2.0"
+	}
+	if len(cfg.Paths) == 0 {
+		cfg.Paths = []string{"**"}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Dependency is a single third-party project this module depends on.
+type Dependency struct {
+	Name    string
+	Version string
+	URL     string
+	License string // SPDX id, or "NOASSERTION" when unknown
+}
+
+// packageJSON is the subset of package.json ScanDependencies cares about.
+type packageJSON struct {
+	Name            string            `json:"name"`
+	Version         string            `json:"version"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+	License         interface{}       `json:"license"`
+	Homepage        string            `json:"homepage"`
+	Repository      interface{}       `json:"repository"`
+}
+
+// ScanDependencies reads package.json (and node_modules for installed
+// metadata) plus go.mod, if present, at root and returns every declared
+// third-party dependency.
+func ScanDependencies(root string) ([]Dependency, error) {
+	deps := map[string]Dependency{}
+
+	if err := scanPackageJSON(root, deps); err != nil {
+		return nil, err
+	}
+	if err := scanGoMod(root, deps); err != nil {
+		return nil, err
+	}
+
+	list := make([]Dependency, 0, len(deps))
+	for _, d := range deps {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
+
+func scanPackageJSON(root string, deps map[string]Dependency) error {
+	data, err := ioutil.ReadFile(filepath.Join(root, "package.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read package.json: %w", err)
+	}
+
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("parse package.json: %w", err)
+	}
+
+	names := make([]string, 0, len(pkg.Dependencies)+len(pkg.DevDependencies))
+	for name := range pkg.Dependencies {
+		names = append(names, name)
+	}
+	for name := range pkg.DevDependencies {
+		names = append(names, name)
+	}
+
+	for _, name := range names {
+		dep := Dependency{Name: name, License: "NOASSERTION"}
+
+		installed, err := ioutil.ReadFile(filepath.Join(root, "node_modules", name, "package.json"))
+		if err == nil {
+			var installedPkg packageJSON
+			if err := json.Unmarshal(installed, &installedPkg); err == nil {
+				dep.Version = installedPkg.Version
+				dep.License = licenseID(installedPkg.License)
+				dep.URL = repositoryURL(installedPkg)
+			}
+		}
+		if dep.Version == "" {
+			dep.Version = pkg.Dependencies[name]
+			if dep.Version == "" {
+				dep.Version = pkg.DevDependencies[name]
+			}
+		}
+
+		deps[name] = dep
+	}
+
+	return nil
+}
+
+func licenseID(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case map[string]interface{}:
+		if s, ok := t["type"].(string); ok {
+			return s
+		}
+	}
+	return "NOASSERTION"
+}
+
+func repositoryURL(pkg packageJSON) string {
+	if pkg.Homepage != "" {
+		return pkg.Homepage
+	}
+	switch t := pkg.Repository.(type) {
+	case string:
+		return normalizeRepoShorthand(t)
+	case map[string]interface{}:
+		if s, ok := t["url"].(string); ok {
+			return normalizeRepoShorthand(s)
+		}
+	}
+	return ""
+}
+
+func normalizeRepoShorthand(s string) string {
+	if strings.HasPrefix(s, "github:") {
+		return "https://github.com/" + strings.TrimPrefix(s, "github:")
+	}
+	s = strings.TrimPrefix(s, "git+")
+	s = strings.TrimSuffix(s, ".git")
+	return s
+}
+
+var goModRequireLine = regexp.MustCompile(`^\s*([^\s]+)\s+(v[^\s]+)`)
+
+func scanGoMod(root string, deps map[string]Dependency) error {
+	data, err := ioutil.ReadFile(filepath.Join(root, "go.mod"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read go.mod: %w", err)
+	}
+
+	inRequireBlock := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "require ("):
+			inRequireBlock = true
+			continue
+		case inRequireBlock && trimmed == ")":
+			inRequireBlock = false
+			continue
+		case inRequireBlock:
+			// fall through to match below
+		case strings.HasPrefix(trimmed, "require "):
+			trimmed = strings.TrimPrefix(trimmed, "require ")
+		default:
+			continue
+		}
+
+		m := goModRequireLine.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+
+		name := m[1]
+		deps[name] = Dependency{
+			Name:    name,
+			Version: m[2],
+			URL:     "https://" + name,
+			License: "NOASSERTION",
+		}
+	}
+
+	return nil
+}
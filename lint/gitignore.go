@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitignoreMatcher matches repo-relative paths against the patterns in a
+// top-level .gitignore file. It supports plain names, directory prefixes
+// and the glob syntax understood by filepath.Match, which covers the
+// patterns this repository's own .gitignore actually uses; it is not a
+// full implementation of git's ignore rules.
+type gitignoreMatcher struct {
+	patterns []string
+}
+
+// loadGitignore reads root/.gitignore. A missing file yields an empty,
+// always-false matcher rather than an error.
+func loadGitignore(root string) (*gitignoreMatcher, error) {
+	data, err := ioutil.ReadFile(filepath.Join(root, ".gitignore"))
+	if os.IsNotExist(err) {
+		return &gitignoreMatcher{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimPrefix(strings.TrimSuffix(line, "/"), "/"))
+	}
+
+	return &gitignoreMatcher{patterns: patterns}, nil
+}
+
+// match reports whether relPath (slash-separated, relative to root) should
+// be ignored.
+func (m *gitignoreMatcher) match(relPath string) bool {
+	for _, p := range m.patterns {
+		if ok, _ := filepath.Match(p, relPath); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, filepath.Base(relPath)); ok {
+			return true
+		}
+		if relPath == p || strings.HasPrefix(relPath, p+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,307 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/chickenlj/dubbo-js/lint/normalizer"
+)
+
+// defaultLicenseLocationThreshold is how many lines past any recognized
+// leading pragma (shebang, XML prolog, Go build constraints, ...) the
+// header may still start and be considered present, when the config
+// doesn't set license-location-threshold explicitly.
+const defaultLicenseLocationThreshold = 4
+
+const utf8BOM = "\uFEFF"
+
+var pythonEncodingDecl = regexp.MustCompile(`^#.*coding[:=]\s*[-\w.]+`)
+
+var goBuildConstraint = regexp.MustCompile(`^//(go:build|\s*\+build)\b`)
+
+// HeaderResult is the outcome of checking a single file's license header.
+type HeaderResult struct {
+	Path    string
+	Present bool
+	Reason  string
+}
+
+// HeaderReport is the structured result of a HeaderLinter.Lint run.
+type HeaderReport struct {
+	Results []HeaderResult
+}
+
+// Missing returns the subset of results whose header is absent or wrong.
+func (r *HeaderReport) Missing() []HeaderResult {
+	var missing []HeaderResult
+	for _, res := range r.Results {
+		if !res.Present {
+			missing = append(missing, res)
+		}
+	}
+	return missing
+}
+
+// OK reports whether every checked file carried the expected header.
+func (r *HeaderReport) OK() bool {
+	return len(r.Missing()) == 0
+}
+
+// HeaderLinter walks a repository and verifies (or inserts) the configured
+// license header in every source file it covers.
+type HeaderLinter struct {
+	Config *Config
+	Root   string
+
+	// File, when set, restricts Lint/Fix to this single path instead of
+	// walking Root. Used by the --file flag for debugging.
+	File string
+}
+
+// Lint checks every resolved file and returns a structured report; it never
+// modifies files on disk.
+func (h *HeaderLinter) Lint() (*HeaderReport, error) {
+	files, err := h.resolveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &HeaderReport{}
+	for _, path := range files {
+		style, ok := styleForFile(h.Config, path)
+		if !ok {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+
+		present, reason := hasHeader(string(content), style, h.Config.License.Content, h.Config.licenseLocationThreshold())
+		report.Results = append(report.Results, HeaderResult{
+			Path:    path,
+			Present: present,
+			Reason:  reason,
+		})
+	}
+
+	return report, nil
+}
+
+// Fix inserts the configured header into every file missing one.
+func (h *HeaderLinter) Fix() error {
+	report, err := h.Lint()
+	if err != nil {
+		return err
+	}
+
+	for _, res := range report.Missing() {
+		style, ok := styleForFile(h.Config, res.Path)
+		if !ok {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(res.Path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", res.Path, err)
+		}
+
+		fixed := spliceHeader(string(content), style.render(h.Config.License.Content))
+		if err := ioutil.WriteFile(res.Path, []byte(fixed), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", res.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// hasHeader reports whether content already carries the configured
+// license text within threshold lines of any recognized leading pragma,
+// and if not, a short human-readable reason why. The comparison is
+// semantic, via normalizer, so differing whitespace or copyright years
+// don't cause a false mismatch.
+func hasHeader(content string, style CommentStyle, license string, threshold int) (bool, string) {
+	if license == "" {
+		return false, "no license.content configured"
+	}
+
+	body := content[leadingPragmaSplice(content):]
+	leading := firstNLines(body, strings.Count(license, "\n")+threshold)
+
+	want := normalizer.Normalize(license, "")
+	got := normalizer.Normalize(leading, "")
+	if strings.Contains(got, want) {
+		return true, ""
+	}
+
+	return false, "missing or non-matching license header"
+}
+
+// firstNLines returns the first n lines of s, or the whole string if it
+// has fewer.
+func firstNLines(s string, n int) string {
+	lines := strings.SplitN(s, "\n", n+1)
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// spliceHeader inserts header into content after any leading pragma lines
+// recognized by leadingPragmaSplice (BOM, shebang, Python encoding
+// declaration, XML declaration, Go build constraints), so those pragmas
+// stay first in the file.
+func spliceHeader(content, header string) string {
+	offset := leadingPragmaSplice(content)
+	return content[:offset] + header + "\n" + content[offset:]
+}
+
+// leadingPragmaSplice returns the byte offset right after any leading
+// pragma lines a header must not be inserted before: a UTF-8 BOM, a
+// shebang line (#!...), a Python encoding declaration, an XML declaration
+// (<?xml ... ?>), or one or more Go build constraints (legacy // +build or
+// //go:build) together with their mandatory trailing blank line.
+func leadingPragmaSplice(content string) int {
+	offset := 0
+
+	if strings.HasPrefix(content, utf8BOM) {
+		offset += len(utf8BOM)
+	}
+
+	if rest := content[offset:]; strings.HasPrefix(rest, "#!") {
+		offset += lineSpan(rest)
+	}
+	if rest := content[offset:]; pythonEncodingDecl.MatchString(firstLine(rest)) {
+		offset += lineSpan(rest)
+	}
+
+	if rest := content[offset:]; strings.HasPrefix(rest, "<?xml") {
+		if idx := strings.Index(rest, "?>"); idx >= 0 {
+			afterDecl := idx + len("?>")
+			offset += afterDecl
+			if nl := strings.IndexByte(rest[afterDecl:], '\n'); nl >= 0 {
+				offset += nl + 1
+			}
+		}
+	}
+
+	sawBuildConstraint := false
+	for {
+		rest := content[offset:]
+		if !goBuildConstraint.MatchString(firstLine(rest)) {
+			break
+		}
+		sawBuildConstraint = true
+		offset += lineSpan(rest)
+	}
+	if sawBuildConstraint {
+		if rest := content[offset:]; firstLine(rest) == "" {
+			offset += lineSpan(rest)
+		}
+	}
+
+	return offset
+}
+
+// firstLine returns s up to but not including its first newline.
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+// lineSpan returns how many bytes of s its first line occupies, including
+// the trailing newline when present.
+func lineSpan(s string) int {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return idx + 1
+	}
+	return len(s)
+}
+
+// resolveFiles expands h.File, or walks h.Root honoring Config.Paths,
+// Config.PathsIgnore and the repository's .gitignore.
+func (h *HeaderLinter) resolveFiles() ([]string, error) {
+	if h.File != "" {
+		return []string{h.File}, nil
+	}
+
+	ignore, err := loadGitignore(h.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	err = filepath.Walk(h.Root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(h.Root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if info.IsDir() {
+			if rel == ".git" {
+				return filepath.SkipDir
+			}
+			if ignore.match(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if ignore.match(rel) || matchesAny(h.Config.PathsIgnore, rel) {
+			return nil
+		}
+		if len(h.Config.Paths) > 0 && !matchesAny(h.Config.Paths, rel) {
+			return nil
+		}
+
+		files = append(files, path)
+		return nil
+	})
+
+	return files, err
+}
+
+// matchesAny reports whether rel matches any of the given glob patterns.
+func matchesAny(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		if p == "**" {
+			return true
+		}
+		if ok, _ := filepath.Match(p, rel); ok {
+			return true
+		}
+		if strings.HasPrefix(rel, strings.TrimSuffix(p, "/**")+"/") {
+			return true
+		}
+	}
+	return false
+}
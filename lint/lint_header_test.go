@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testConfig() *Config {
+	return &Config{
+		Paths: []string{"**"},
+		License: LicenseConfig{
+			SPDXID:  "Apache-2.0",
+			Content: "Licensed under the Apache License, Version 2.0.",
+		},
+	}
+}
+
+func TestHeaderLinter_lint_missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "no_header.go")
+	if err := ioutil.WriteFile(path, []byte("package foo\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	l := &HeaderLinter{Config: testConfig(), File: path}
+	report, err := l.Lint()
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if report.OK() {
+		t.Fatalf("expected a missing header to be reported")
+	}
+}
+
+func TestHeaderLinter_fix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "needs_header.go")
+	if err := ioutil.WriteFile(path, []byte("package foo\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	l := &HeaderLinter{Config: testConfig(), File: path}
+	if err := l.Fix(); err != nil {
+		t.Fatalf("fix error: %v", err)
+	}
+
+	report, err := l.Lint()
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("expected header to be present after fix")
+	}
+}
+
+func TestHeaderLinter_fix_preservesShebang(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.sh")
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\necho hi\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	l := &HeaderLinter{Config: testConfig(), File: path}
+	if err := l.Fix(); err != nil {
+		t.Fatalf("fix error: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixed file: %v", err)
+	}
+	if got := string(content)[:len("#!/bin/sh")]; got != "#!/bin/sh" {
+		t.Fatalf("expected shebang to remain first, got %q", got)
+	}
+}
+
+func TestHeaderLinter_fix_preservesXMLProlog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.xml")
+	if err := ioutil.WriteFile(path, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<root/>\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	l := &HeaderLinter{Config: testConfig(), File: path}
+	if err := l.Fix(); err != nil {
+		t.Fatalf("fix error: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixed file: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n") {
+		t.Fatalf("expected XML prolog to remain first, got %q", content)
+	}
+
+	report, err := l.Lint()
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("expected header to be recognized after the XML prolog")
+	}
+}
+
+func TestHeaderLinter_fix_preservesGoBuildConstraint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "build_tag.go")
+	if err := ioutil.WriteFile(path, []byte("//go:build linux\n// +build linux\n\npackage foo\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	l := &HeaderLinter{Config: testConfig(), File: path}
+	if err := l.Fix(); err != nil {
+		t.Fatalf("fix error: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixed file: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "//go:build linux\n// +build linux\n\n") {
+		t.Fatalf("expected build constraints and their blank line to remain first, got %q", content)
+	}
+
+	report, err := l.Lint()
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("expected header to be recognized after the build constraints")
+	}
+}
+
+func TestHeaderLinter_fix_preservesPythonEncodingDeclaration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.py")
+	if err := ioutil.WriteFile(path, []byte("#!/usr/bin/env python\n# -*- coding: utf-8 -*-\nprint('hi')\n"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	l := &HeaderLinter{Config: testConfig(), File: path}
+	if err := l.Fix(); err != nil {
+		t.Fatalf("fix error: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixed file: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "#!/usr/bin/env python\n# -*- coding: utf-8 -*-\n") {
+		t.Fatalf("expected shebang and encoding declaration to remain first, got %q", content)
+	}
+
+	report, err := l.Lint()
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("expected header to be recognized after the encoding declaration")
+	}
+}
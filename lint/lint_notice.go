@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/chickenlj/dubbo-js/lint/normalizer"
+)
+
+// defaultNotice is the canonical NOTICE content every checked-in NOTICE file
+// must match, modulo whitespace and copyright year differences.
+const defaultNotice = `Apache Dubbo-go
+Copyright 1999-2021 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+`
+
+// NoticeLinter lints and fixes a single top-level NOTICE file.
+//
+// Root and Policy are optional. When Root is set, lint also verifies the
+// NOTICE's generated dependency section against the dependencies actually
+// declared under Root, enforcing Policy along the way.
+type NoticeLinter struct {
+	File   string
+	Root   string
+	Policy PolicyConfig
+}
+
+// lint reports an error if the NOTICE file is missing, its static content
+// does not match the expected NOTICE text, or (when Root is set) its
+// dependency section is out of date or violates the license policy.
+func (l NoticeLinter) lint() error {
+	content, err := ioutil.ReadFile(l.File)
+	if err != nil {
+		return fmt.Errorf("read notice file %s: %w", l.File, err)
+	}
+
+	static, _, _ := splitDependencySection(string(content))
+	if ok, diff := noticeMatches(static); !ok {
+		return fmt.Errorf("notice file %s does not match the expected content, run fix to repair it\n%s", l.File, diff)
+	}
+
+	if l.Root == "" {
+		return nil
+	}
+
+	deps, err := ScanDependencies(l.Root)
+	if err != nil {
+		return fmt.Errorf("scan dependencies: %w", err)
+	}
+	if err := checkPolicy(deps, l.Policy); err != nil {
+		return err
+	}
+	if err := verifyDependencySection(string(content), deps); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// fix rewrites the NOTICE file with the canonical content. When Root is
+// set, it also regenerates the dependency section rather than dropping it.
+func (l NoticeLinter) fix() error {
+	content := defaultNotice
+
+	if l.Root != "" {
+		deps, err := ScanDependencies(l.Root)
+		if err != nil {
+			return fmt.Errorf("scan dependencies: %w", err)
+		}
+		content = GenerateNotice(content, deps)
+	}
+
+	return ioutil.WriteFile(l.File, []byte(content), 0644)
+}
+
+// noticeMatches compares content against defaultNotice semantically, via
+// the same normalizer used by the header linter, so whitespace and
+// copyright-year differences don't cause a false failure.
+func noticeMatches(content string) (bool, string) {
+	got := normalizer.Normalize(content, "")
+	want := normalizer.Normalize(defaultNotice, "")
+	if got == want {
+		return true, ""
+	}
+	return false, fmt.Sprintf("expected: %s\ngot:      %s", want, got)
+}
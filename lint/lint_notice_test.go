@@ -19,12 +19,13 @@ package main
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 )
 
 func TestNoticeLinter_lint(t *testing.T) {
 	l := NoticeLinter{
-		File: "./__notice__/NOTICE",
+		File: filepath.Join(t.TempDir(), "NOTICE"),
 	}
 	err := l.lint()
 	if err == nil {
@@ -34,11 +35,14 @@ func TestNoticeLinter_lint(t *testing.T) {
 
 func TestNoticeLinter_fixed(t *testing.T) {
 	// write test data
-	ioutil.WriteFile("./__notice__/NOTICE_1", []byte("Copyright 2018-2021 The Apache Software Foundation"), 0644)
+	path := filepath.Join(t.TempDir(), "NOTICE_1")
+	if err := ioutil.WriteFile(path, []byte("Copyright 2018-2021 The Apache Software Foundation"), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
 
 	// init notice linter
 	l := NoticeLinter{
-		File: "./__notice__/NOTICE_1",
+		File: path,
 	}
 
 	// fixed notice
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: lint <lint|fix|notice generate> [--config .licenserc.yaml] [--file path]")
+		os.Exit(1)
+	}
+
+	cmd := os.Args[1]
+
+	if cmd == "notice" {
+		runNotice(os.Args[2:])
+		return
+	}
+
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigFile, "path to the license checker config")
+	file := fs.String("file", "", "check or fix a single file instead of the whole repository")
+	noticeFile := fs.String("notice", "NOTICE", "path to the NOTICE file")
+	fs.Parse(os.Args[2:])
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	linter := &HeaderLinter{Config: cfg, Root: ".", File: *file}
+
+	switch cmd {
+	case "lint":
+		report, err := linter.Lint()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		for _, res := range report.Missing() {
+			fmt.Printf("%s: %s\n", res.Path, res.Reason)
+		}
+
+		notice := NoticeLinter{File: *noticeFile, Root: ".", Policy: cfg.Policy}
+		if err := notice.lint(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if !report.OK() {
+			os.Exit(1)
+		}
+	case "fix":
+		if err := linter.Fix(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		notice := NoticeLinter{File: *noticeFile, Root: ".", Policy: cfg.Policy}
+		if err := notice.lint(); err != nil {
+			if err := notice.fix(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q, expected lint, fix or notice\n", cmd)
+		os.Exit(1)
+	}
+}
+
+// runNotice handles the "notice" command group, e.g. "lint notice generate".
+func runNotice(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "usage: lint notice generate [--config .licenserc.yaml] [--notice NOTICE]")
+		os.Exit(1)
+	}
+
+	sub := args[0]
+	if sub != "generate" {
+		fmt.Fprintf(os.Stderr, "unknown notice subcommand %q, expected generate\n", sub)
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("notice "+sub, flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigFile, "path to the license checker config")
+	noticeFile := fs.String("notice", "NOTICE", "path to the NOTICE file")
+	fs.Parse(args[1:])
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	deps, err := ScanDependencies(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := checkPolicy(deps, cfg.Policy); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	existing, err := ioutil.ReadFile(*noticeFile)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	updated := GenerateNotice(string(existing), deps)
+	if err := ioutil.WriteFile(*noticeFile, []byte(updated), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
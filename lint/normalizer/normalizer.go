@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package normalizer compares license texts for semantic rather than exact
+// equality, so a header that differs from the canonical SPDX text only in
+// whitespace, comment markers or copyright years still matches.
+package normalizer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// commentLeaders strips per-line comment markers regardless of which
+// language the file is written in; run before whitespace collapsing so the
+// leaders don't leave stray single characters behind.
+var commentLeaders = regexp.MustCompile(`(?m)^[ \t]*(//|/\*|\*/|\*|#|<!--|-->|;|%)[ \t]?`)
+
+var copyrightLine = regexp.MustCompile(`(?i)copyright\s+(\d{4}(-\d{4})?)\s+.+`)
+
+var whitespaceRun = regexp.MustCompile(`\s+`)
+
+var punctuationNoise = regexp.MustCompile(`[•\-*]{2,}`)
+
+// Normalize reduces text to a canonical form for comparison: comment
+// leaders and punctuation noise are stripped, whitespace runs collapse to
+// a single space, the result is lowercased, and copyright lines are
+// replaced with a stable placeholder so differing years or holder names
+// don't cause a false mismatch. The style parameter is accepted for
+// callers that want to document which comment style text came from; the
+// pipeline itself strips every known leader regardless of style.
+func Normalize(text, style string) string {
+	out := commentLeaders.ReplaceAllString(text, "")
+	out = copyrightLine.ReplaceAllString(out, "<copyright>")
+	out = punctuationNoise.ReplaceAllString(out, " ")
+	out = whitespaceRun.ReplaceAllString(out, " ")
+	out = strings.ToLower(strings.TrimSpace(out))
+	return out
+}
+
+// Match reports whether text is a semantic match for the embedded SPDX
+// template identified by spdxID. On mismatch it also returns a short diff
+// to help a caller show the user what differed.
+func Match(text, spdxID string) (bool, string) {
+	template, ok := templates[spdxID]
+	if !ok {
+		return false, fmt.Sprintf("no embedded license template for spdx-id %q", spdxID)
+	}
+
+	got := Normalize(text, "")
+	want := Normalize(template, "")
+	if got == want {
+		return true, ""
+	}
+
+	if fallback, ok := fallbackPatterns[spdxID]; ok && fallback.MatchString(got) {
+		return true, ""
+	}
+
+	return false, diff(want, got)
+}
+
+// diff produces a short two-line comparison; texts are often long, so it
+// is deliberately not a full line-by-line diff.
+func diff(want, got string) string {
+	return fmt.Sprintf("expected: %s\ngot:      %s", truncate(want), truncate(got))
+}
+
+func truncate(s string) string {
+	const max = 200
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
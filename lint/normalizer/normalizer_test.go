@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package normalizer
+
+import "testing"
+
+func TestMatch_sameTextDifferentCommentStyle(t *testing.T) {
+	slashslash := `// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+`
+
+	ok, diff := Match(slashslash, "Apache-2.0")
+	if !ok {
+		t.Fatalf("expected match, got diff:\n%s", diff)
+	}
+}
+
+func TestMatch_mismatch(t *testing.T) {
+	ok, diff := Match("this is not a license header", "Apache-2.0")
+	if ok {
+		t.Fatalf("expected mismatch")
+	}
+	if diff == "" {
+		t.Fatalf("expected a non-empty diff on mismatch")
+	}
+}
+
+func TestMatch_unknownSPDXID(t *testing.T) {
+	ok, _ := Match("anything", "MIT")
+	if ok {
+		t.Fatalf("expected no match for an unembedded spdx-id")
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dependencyBeginMarker and dependencyEndMarker delimit the generated
+// dependency section of a NOTICE file, so hand-written sections above and
+// below are left untouched by generate/fix.
+const (
+	dependencyBeginMarker = "# BEGIN dependencies"
+	dependencyEndMarker   = "# END dependencies"
+)
+
+// PolicyConfig is an allow/deny list of SPDX license ids. Deny wins: a
+// license on both lists is rejected. An empty Allow list means every
+// license not on Deny is accepted.
+type PolicyConfig struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// checkPolicy returns an error naming every dependency whose license the
+// policy rejects.
+func checkPolicy(deps []Dependency, policy PolicyConfig) error {
+	var violations []string
+	for _, dep := range deps {
+		if contains(policy.Deny, dep.License) {
+			violations = append(violations, fmt.Sprintf("%s is %s, which is denied", dep.Name, dep.License))
+			continue
+		}
+		if len(policy.Allow) > 0 && !contains(policy.Allow, dep.License) {
+			violations = append(violations, fmt.Sprintf("%s is %s, which is not in the allow list", dep.Name, dep.License))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("license policy violations:\n%s", strings.Join(violations, "\n"))
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderDependencySection formats deps, grouped by SPDX id, as the
+// generated body placed between the dependency markers.
+func renderDependencySection(deps []Dependency) string {
+	byLicense := map[string][]Dependency{}
+	for _, d := range deps {
+		byLicense[d.License] = append(byLicense[d.License], d)
+	}
+
+	licenses := make([]string, 0, len(byLicense))
+	for id := range byLicense {
+		licenses = append(licenses, id)
+	}
+	sort.Strings(licenses)
+
+	var b strings.Builder
+	b.WriteString(dependencyBeginMarker + "\n")
+	for _, id := range licenses {
+		b.WriteString(id + ":\n")
+		group := byLicense[id]
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+		for _, d := range group {
+			b.WriteString(fmt.Sprintf("  %s %s - %s\n", d.Name, d.Version, d.URL))
+		}
+	}
+	b.WriteString(dependencyEndMarker + "\n")
+	return b.String()
+}
+
+// GenerateNotice splices a freshly rendered dependency section into
+// existing NOTICE content, replacing any previous generated section
+// in-place and leaving hand-written text outside the markers untouched.
+// If existing has no markers, the section is appended.
+func GenerateNotice(existing string, deps []Dependency) string {
+	section := renderDependencySection(deps)
+
+	begin := strings.Index(existing, dependencyBeginMarker)
+	end := strings.Index(existing, dependencyEndMarker)
+	if begin == -1 || end == -1 || end < begin {
+		if existing != "" && !strings.HasSuffix(existing, "\n") {
+			existing += "\n"
+		}
+		return existing + "\n" + section
+	}
+
+	end += len(dependencyEndMarker)
+	// section already ends in a newline; drop the old one so regenerating
+	// doesn't add a blank line to the hand-written text below the marker.
+	if strings.HasPrefix(existing[end:], "\n") {
+		end++
+	}
+	return existing[:begin] + section + existing[end:]
+}
+
+// splitDependencySection removes the generated dependency section (if any)
+// from content, returning the remaining static text and the section that
+// was removed.
+func splitDependencySection(content string) (static, section string, hasSection bool) {
+	begin := strings.Index(content, dependencyBeginMarker)
+	end := strings.Index(content, dependencyEndMarker)
+	if begin == -1 || end == -1 || end < begin {
+		return content, "", false
+	}
+
+	end += len(dependencyEndMarker)
+	return content[:begin] + content[end:], content[begin:end], true
+}
+
+// verifyDependencySection checks that the NOTICE content's generated
+// section lists exactly the given dependencies: nothing newly declared is
+// missing, and nothing removed is still listed.
+func verifyDependencySection(content string, deps []Dependency) error {
+	declared := map[string]bool{}
+	for _, d := range deps {
+		declared[d.Name] = true
+	}
+
+	begin := strings.Index(content, dependencyBeginMarker)
+	end := strings.Index(content, dependencyEndMarker)
+	if begin == -1 || end == -1 || end < begin {
+		if len(deps) == 0 {
+			return nil
+		}
+		return fmt.Errorf("NOTICE has no dependency section but %d dependencies are declared, run generate", len(deps))
+	}
+
+	// Slice strictly between the markers so neither marker line itself is
+	// mistaken for a dependency line.
+	section := content[begin+len(dependencyBeginMarker) : end]
+	listed := map[string]bool{}
+	for _, line := range strings.Split(section, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		listed[fields[0]] = true
+	}
+
+	var missing, stale []string
+	for name := range declared {
+		if !listed[name] {
+			missing = append(missing, name)
+		}
+	}
+	for name := range listed {
+		if !declared[name] {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+
+	if len(missing) > 0 || len(stale) > 0 {
+		return fmt.Errorf("NOTICE dependency section is out of date: missing %v, stale %v, run notice generate", missing, stale)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateNotice_preservesHandWrittenText(t *testing.T) {
+	existing := "Apache Foo\nCopyright 2021 The Apache Software Foundation\n"
+	deps := []Dependency{{Name: "left-pad", Version: "1.3.0", URL: "https://github.com/left-pad/left-pad", License: "MIT"}}
+
+	updated := GenerateNotice(existing, deps)
+
+	if !strings.HasPrefix(updated, existing) {
+		t.Fatalf("expected hand-written text to survive, got:\n%s", updated)
+	}
+	if !strings.Contains(updated, "left-pad") {
+		t.Fatalf("expected generated section to list left-pad, got:\n%s", updated)
+	}
+}
+
+func TestGenerateNotice_regeneratesInPlace(t *testing.T) {
+	existing := GenerateNotice("hand-written\n", []Dependency{{Name: "old-dep", Version: "1.0.0", License: "MIT"}})
+
+	updated := GenerateNotice(existing, []Dependency{{Name: "new-dep", Version: "2.0.0", License: "MIT"}})
+
+	if strings.Contains(updated, "old-dep") {
+		t.Fatalf("expected stale dependency to be gone, got:\n%s", updated)
+	}
+	if !strings.Contains(updated, "new-dep") || !strings.Contains(updated, "hand-written") {
+		t.Fatalf("expected new dependency and hand-written text, got:\n%s", updated)
+	}
+}
+
+func TestVerifyDependencySection_matchingSectionIsValid(t *testing.T) {
+	deps := []Dependency{{Name: "left-pad", Version: "1.3.0", URL: "https://github.com/left-pad/left-pad", License: "MIT"}}
+	content := GenerateNotice("hand-written\n", deps)
+
+	if err := verifyDependencySection(content, deps); err != nil {
+		t.Fatalf("expected a freshly generated section to verify clean, got: %v", err)
+	}
+}
+
+func TestVerifyDependencySection_detectsMissingAndStale(t *testing.T) {
+	content := GenerateNotice("", []Dependency{{Name: "old-dep", Version: "1.0.0", License: "MIT"}})
+
+	err := verifyDependencySection(content, []Dependency{{Name: "new-dep", Version: "1.0.0", License: "MIT"}})
+	if err == nil {
+		t.Fatalf("expected an error for a mismatched dependency section")
+	}
+}
+
+func TestCheckPolicy_deniesListedLicense(t *testing.T) {
+	err := checkPolicy([]Dependency{{Name: "bad-dep", License: "GPL-3.0"}}, PolicyConfig{Deny: []string{"GPL-3.0"}})
+	if err == nil {
+		t.Fatalf("expected denied license to fail policy check")
+	}
+}